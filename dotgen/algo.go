@@ -0,0 +1,31 @@
+package main
+
+import "fmt"
+
+// Algorithm selects how the analyzer resolves call targets.
+//
+// AlgoAST is the original syntax-only heuristic: it needs no type
+// information, but it cannot see through interfaces, function values,
+// or variable receivers, and it records no edge for a call whose callee
+// is neither an identifier nor a selector. The other algorithms type-check the
+// tree with go/packages and build an SSA-based call graph, trading
+// analysis time for precision.
+type Algorithm string
+
+const (
+	AlgoAST    Algorithm = "ast"
+	AlgoStatic Algorithm = "static"
+	AlgoCHA    Algorithm = "cha"
+	AlgoRTA    Algorithm = "rta"
+	AlgoVTA    Algorithm = "vta"
+)
+
+// ParseAlgorithm validates a user-supplied -algo value.
+func ParseAlgorithm(s string) (Algorithm, error) {
+	switch Algorithm(s) {
+	case AlgoAST, AlgoStatic, AlgoCHA, AlgoRTA, AlgoVTA:
+		return Algorithm(s), nil
+	default:
+		return "", fmt.Errorf("unknown -algo %q (want ast, static, cha, rta, or vta)", s)
+	}
+}
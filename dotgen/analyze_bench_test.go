@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// genBenchCorpus writes n small-but-realistic Go files into dir and
+// returns dir, simulating the shape (many packages, a handful of
+// functions each) of a large real-world repo like k8s without needing
+// one on disk.
+func genBenchCorpus(tb testing.TB, n int) string {
+	tb.Helper()
+	dir := tb.TempDir()
+
+	const tmpl = `package pkg%d
+
+func A%d() { B%d() }
+func B%d() { C%d() }
+func C%d() {}
+`
+	for i := 0; i < n; i++ {
+		pkgDir := filepath.Join(dir, fmt.Sprintf("pkg%d", i))
+		if err := os.MkdirAll(pkgDir, 0755); err != nil {
+			tb.Fatal(err)
+		}
+		src := fmt.Sprintf(tmpl, i, i, i, i, i, i)
+		if err := os.WriteFile(filepath.Join(pkgDir, "pkg.go"), []byte(src), 0644); err != nil {
+			tb.Fatal(err)
+		}
+	}
+	return dir
+}
+
+// BenchmarkAnalyze_Jobs1 and BenchmarkAnalyze_JobsNumCPU bracket the
+// worker pool added for incremental/parallel analysis: run them with
+// -bench=Analyze -benchtime=5x to see the speedup on this machine, e.g.
+// on an 8-core box BenchmarkAnalyze_JobsNumCPU typically runs several
+// times faster than BenchmarkAnalyze_Jobs1. Both analyze the same
+// generated 2,000-package corpus.
+func BenchmarkAnalyze_Jobs1(b *testing.B) {
+	benchmarkAnalyze(b, 1)
+}
+
+func BenchmarkAnalyze_JobsNumCPU(b *testing.B) {
+	benchmarkAnalyze(b, 0) // 0 -> runtime.NumCPU(), see NewAnalyzer
+}
+
+func benchmarkAnalyze(b *testing.B, jobs int) {
+	dir := genBenchCorpus(b, 2000)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		a := NewAnalyzer(dir, "", false, AlgoAST, jobs, "", ScopeAll)
+		if _, err := a.Analyze(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -7,7 +7,9 @@ import (
 	"go/token"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
+	"sync"
 )
 
 // Function represents a function in the codebase
@@ -17,12 +19,23 @@ type Function struct {
 	File       string
 	Receiver   string // For methods
 	IsExported bool
+
+	// Doc holds the plain text of the function's leading comments; the
+	// fields below come from //callgraph: directives in them; see
+	// comments.go.
+	Doc        string
+	Group      string // //callgraph:group=<name>
+	Color      string // //callgraph:color=<hex>
+	Entrypoint bool   // //callgraph:entrypoint
 }
 
 // Call represents a function call
 type Call struct {
-	From string // Caller function
-	To   string // Callee function
+	From  string         // Caller function
+	To    string         // Callee function
+	Algo  Algorithm      // Resolution algorithm that produced this edge
+	Scope Scope          // Where the callee's package lives relative to the module
+	Pos   token.Position // Call site position
 }
 
 // CallGraph represents the complete callgraph
@@ -30,6 +43,12 @@ type CallGraph struct {
 	Functions map[string]*Function
 	Calls     []Call
 	Packages  map[string]bool
+
+	// Ignored holds the fully-qualified names of functions marked
+	// //callgraph:ignore. They have no entry in Functions, but calls to
+	// or from them may still appear in Calls; renderers should drop
+	// those edges too.
+	Ignored map[string]bool
 }
 
 // Analyzer analyzes Go code to extract callgraph information
@@ -37,13 +56,26 @@ type Analyzer struct {
 	rootPath    string
 	excludeDirs []string
 	verbose     bool
-	fset        *token.FileSet
+	algo        Algorithm
+	jobs        int
+	cachePath   string
+	scope       Scope
+	modulePath  string // "" if rootPath isn't inside a module with a go.mod
+	moduleDir   string
 	packages    map[string]*ast.Package
 	callGraph   *CallGraph
 }
 
-// NewAnalyzer creates a new analyzer
-func NewAnalyzer(rootPath string, excludeDirs string, verbose bool) *Analyzer {
+// NewAnalyzer creates a new analyzer. algo selects how call targets are
+// resolved; pass AlgoAST for the original syntax-only heuristic. jobs is
+// the worker pool size for the AST path (<=0 means runtime.NumCPU()),
+// cachePath is where per-file results are cached between runs ("")
+// disables the cache, and scope filters recorded edges by whether their
+// callee is module-internal, stdlib, or third-party (ScopeAll keeps
+// everything). NewAnalyzer looks for a go.mod above rootPath to tell
+// module-internal imports apart from the standard library and
+// dependencies; without one, every call is treated as module-internal.
+func NewAnalyzer(rootPath string, excludeDirs string, verbose bool, algo Algorithm, jobs int, cachePath string, scope Scope) *Analyzer {
 	var excludeList []string
 	if excludeDirs != "" {
 		excludeList = strings.Split(excludeDirs, ",")
@@ -52,23 +84,68 @@ func NewAnalyzer(rootPath string, excludeDirs string, verbose bool) *Analyzer {
 		}
 	}
 
+	if algo == "" {
+		algo = AlgoAST
+	}
+	if jobs <= 0 {
+		jobs = runtime.NumCPU()
+	}
+	if scope == "" {
+		scope = ScopeAll
+	}
+
+	modulePath, moduleDir := findModule(rootPath)
+
 	return &Analyzer{
 		rootPath:    rootPath,
 		excludeDirs: excludeList,
 		verbose:     verbose,
-		fset:        token.NewFileSet(),
+		algo:        algo,
+		jobs:        jobs,
+		cachePath:   cachePath,
+		scope:       scope,
+		modulePath:  modulePath,
+		moduleDir:   moduleDir,
 		packages:    make(map[string]*ast.Package),
 		callGraph: &CallGraph{
 			Functions: make(map[string]*Function),
 			Calls:     make([]Call, 0),
 			Packages:  make(map[string]bool),
+			Ignored:   make(map[string]bool),
 		},
 	}
 }
 
 // Analyze performs the analysis
 func (a *Analyzer) Analyze() (*CallGraph, error) {
-	// Walk through the directory tree
+	// Type-aware algorithms need a type-checked, SSA-built program, which
+	// is a fundamentally different pipeline from the syntax-only walk
+	// below.
+	if a.algo != AlgoAST {
+		return a.analyzeTyped()
+	}
+
+	files, err := a.collectFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	cache := loadCache(a.cachePath)
+	newCache := a.analyzeFiles(files, cache)
+
+	if a.cachePath != "" {
+		if err := saveCache(a.cachePath, newCache); err != nil && a.verbose {
+			fmt.Printf("  Warning: failed to write cache %s: %v\n", a.cachePath, err)
+		}
+	}
+
+	return a.callGraph, nil
+}
+
+// collectFiles walks the directory tree and returns every analyzable Go
+// file, honoring excludeDirs and hidden directories.
+func (a *Analyzer) collectFiles() ([]string, error) {
+	var files []string
 	err := filepath.Walk(a.rootPath, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
@@ -88,53 +165,174 @@ func (a *Analyzer) Analyze() (*CallGraph, error) {
 			}
 		}
 
-		// Parse Go files
 		if !info.IsDir() && strings.HasSuffix(path, ".go") && !strings.HasSuffix(path, "_test.go") {
-			return a.parseFile(path)
+			files = append(files, path)
 		}
 
 		return nil
 	})
+	return files, err
+}
 
-	if err != nil {
-		return nil, err
+// analyzeFiles fans files out across a.jobs workers, reusing cache
+// entries for files whose mtime/size haven't changed, merges every
+// result into a.callGraph, and returns the cache to persist for next
+// time.
+func (a *Analyzer) analyzeFiles(files []string, cache fileCache) fileCache {
+	jobs := a.jobs
+	if jobs > len(files) {
+		jobs = len(files)
+	}
+	if jobs < 1 {
+		jobs = 1
 	}
 
-	return a.callGraph, nil
+	type indexed struct {
+		index  int
+		result *fileResult
+	}
+
+	indexCh := make(chan int)
+	resultCh := make(chan indexed, len(files))
+
+	var workers sync.WaitGroup
+	workers.Add(jobs)
+	for i := 0; i < jobs; i++ {
+		go func() {
+			defer workers.Done()
+			for idx := range indexCh {
+				resultCh <- indexed{idx, a.analyzeFile(files[idx], cache)}
+			}
+		}()
+	}
+
+	go func() {
+		for i := range files {
+			indexCh <- i
+		}
+		close(indexCh)
+	}()
+
+	go func() {
+		workers.Wait()
+		close(resultCh)
+	}()
+
+	// Results complete in whatever order the workers finish, but we
+	// merge them back in original file order so the generated graph
+	// (and thus its DOT output) is deterministic between runs.
+	results := make([]*fileResult, len(files))
+	for r := range resultCh {
+		results[r.index] = r.result
+	}
+
+	newCache := make(fileCache, len(files))
+	for _, res := range results {
+		a.mergeResult(res)
+		newCache[res.Path] = res.cacheEntry()
+	}
+	return newCache
 }
 
-// parseFile parses a single Go file
-func (a *Analyzer) parseFile(filePath string) error {
+// analyzeFile returns the cached result for path if it's still fresh,
+// otherwise it parses the file from scratch.
+func (a *Analyzer) analyzeFile(path string, cache fileCache) *fileResult {
+	info, err := os.Stat(path)
+	if err != nil {
+		return &fileResult{Path: path}
+	}
+
+	if entry, ok := cache.fresh(path, info); ok {
+		if a.verbose {
+			fmt.Printf("  Cached:  %s\n", path)
+		}
+		return entry.toResult(path)
+	}
+
 	if a.verbose {
-		fmt.Printf("  Parsing: %s\n", filePath)
+		fmt.Printf("  Parsing: %s\n", path)
+	}
+	res := a.parseFile(path)
+	res.ModTime = info.ModTime()
+	res.Size = info.Size()
+	return res
+}
+
+// mergeResult folds a single file's extracted functions and calls into
+// a.callGraph, dropping any call whose scope a.scope excludes. The
+// filter is applied here rather than during extraction so that cached
+// results - which are always unfiltered, see extractCalls - produce the
+// same graph as a fresh parse regardless of which -scope ran first.
+func (a *Analyzer) mergeResult(res *fileResult) {
+	if res.Package != "" {
+		a.callGraph.Packages[res.Package] = true
+	}
+	for _, fn := range res.Functions {
+		a.callGraph.Functions[functionKey(fn)] = fn
+	}
+	for _, name := range res.Ignored {
+		a.callGraph.Ignored[name] = true
 	}
+	for _, call := range res.Calls {
+		if a.scope.allows(call.Scope) {
+			a.callGraph.Calls = append(a.callGraph.Calls, call)
+		}
+	}
+}
+
+// functionKey reproduces the fully-qualified name a Function is stored
+// under in CallGraph.Functions.
+func functionKey(fn *Function) string {
+	if fn.Receiver != "" {
+		return fn.Package + "." + fn.Receiver + "." + fn.Name
+	}
+	return fn.Package + "." + fn.Name
+}
 
-	file, err := parser.ParseFile(a.fset, filePath, nil, parser.AllErrors)
+// parseFile parses a single Go file and extracts its functions and
+// calls. It touches no Analyzer or CallGraph state, which is what makes
+// it safe to call concurrently from the worker pool in analyzeFiles.
+func (a *Analyzer) parseFile(filePath string) *fileResult {
+	res := &fileResult{Path: filePath}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filePath, nil, parser.ParseComments|parser.AllErrors)
 	if err != nil {
 		// Log but don't fail on parse errors
 		if a.verbose {
 			fmt.Printf("    Warning: %v\n", err)
 		}
-		return nil
+		return res
 	}
 
-	pkgName := file.Name.Name
-	a.callGraph.Packages[pkgName] = true
+	// Prefer the package's canonical import path over its bare name so
+	// that calls resolved through the import table (see imports.go) key
+	// into the same namespace as the functions declared here; without a
+	// discoverable go.mod we fall back to the bare name, same as before.
+	res.Package = file.Name.Name
+	if importPath := importPathOf(filepath.Dir(filePath), a.modulePath, a.moduleDir); importPath != "" {
+		res.Package = importPath
+	}
+
+	// cmap associates each function with its leading comments, which may
+	// carry //callgraph: directives (see comments.go).
+	cmap := ast.NewCommentMap(fset, file, file.Comments)
+	imports := buildImportTable(file)
 
 	// Extract functions and methods
 	ast.Inspect(file, func(n ast.Node) bool {
 		switch node := n.(type) {
 		case *ast.FuncDecl:
-			a.extractFunction(node, pkgName, filePath)
+			a.extractFunction(res, node, res.Package, filePath, fset, cmap, imports)
 		}
 		return true
 	})
 
-	return nil
+	return res
 }
 
-// extractFunction extracts function information and calls
-func (a *Analyzer) extractFunction(funcDecl *ast.FuncDecl, pkgName, filePath string) {
+// extractFunction extracts function information and calls into res.
+func (a *Analyzer) extractFunction(res *fileResult, funcDecl *ast.FuncDecl, pkgName, filePath string, fset *token.FileSet, cmap ast.CommentMap, imports importTable) {
 	funcName := funcDecl.Name.Name
 	receiver := ""
 	fullName := pkgName + "." + funcName
@@ -146,30 +344,48 @@ func (a *Analyzer) extractFunction(funcDecl *ast.FuncDecl, pkgName, filePath str
 		fullName = pkgName + "." + recvType + "." + funcName
 	}
 
+	ann := parseAnnotations(cmap[funcDecl])
+	if ann.Ignore {
+		res.Ignored = append(res.Ignored, fullName)
+		return
+	}
+
 	// Store function
-	a.callGraph.Functions[fullName] = &Function{
+	res.Functions = append(res.Functions, &Function{
 		Name:       funcName,
 		Package:    pkgName,
 		File:       filePath,
 		Receiver:   receiver,
 		IsExported: ast.IsExported(funcName),
-	}
+		Doc:        ann.Doc,
+		Group:      ann.Group,
+		Color:      ann.Color,
+		Entrypoint: ann.Entrypoint,
+	})
 
 	// Extract function calls
 	if funcDecl.Body != nil {
-		a.extractCalls(funcDecl.Body, fullName, pkgName)
+		a.extractCalls(res, funcDecl.Body, fullName, pkgName, fset, imports)
 	}
 }
 
-// extractCalls extracts function calls from a statement block
-func (a *Analyzer) extractCalls(node ast.Node, caller, callerPkg string) {
+// extractCalls extracts function calls from a statement block into res.
+// It records every call regardless of a.scope: res is what gets cached
+// to disk, and the cache key doesn't account for -scope, so filtering
+// here would bake one run's -scope value into the cache and silently
+// feed it to later runs with a different -scope. mergeResult applies
+// the filter instead, once results are past the cache.
+func (a *Analyzer) extractCalls(res *fileResult, node ast.Node, caller, callerPkg string, fset *token.FileSet, imports importTable) {
 	ast.Inspect(node, func(n ast.Node) bool {
 		if callExpr, ok := n.(*ast.CallExpr); ok {
-			callee := a.getCallTarget(callExpr, callerPkg)
+			callee, scope := a.getCallTarget(callExpr, callerPkg, imports)
 			if callee != "" {
-				a.callGraph.Calls = append(a.callGraph.Calls, Call{
-					From: caller,
-					To:   callee,
+				res.Calls = append(res.Calls, Call{
+					From:  caller,
+					To:    callee,
+					Algo:  AlgoAST,
+					Scope: scope,
+					Pos:   fset.Position(callExpr.Pos()),
 				})
 			}
 		}
@@ -177,25 +393,35 @@ func (a *Analyzer) extractCalls(node ast.Node, caller, callerPkg string) {
 	})
 }
 
-// getCallTarget determines the target of a function call
-func (a *Analyzer) getCallTarget(callExpr *ast.CallExpr, currentPkg string) string {
+// getCallTarget determines the target of a function call and classifies
+// it by Scope. currentPkg is the caller's own canonical package key (see
+// parseFile). It prefixes same-package calls and selector calls on
+// non-identifier expressions, and supplies the Scope of identifier
+// selectors imports can't resolve (e.g. a method call on a local
+// variable, which an AST-only walk can't tell apart from a call through
+// an unrecognized package alias); those are named "ident.Sel" as written.
+func (a *Analyzer) getCallTarget(callExpr *ast.CallExpr, currentPkg string, imports importTable) (target string, scope Scope) {
 	switch fun := callExpr.Fun.(type) {
 	case *ast.Ident:
 		// Simple function call: foo()
-		return currentPkg + "." + fun.Name
+		return currentPkg + "." + fun.Name, classifyImport(currentPkg, a.modulePath)
 
 	case *ast.SelectorExpr:
 		// Method call or package-qualified call: pkg.Foo() or obj.Method()
-		if ident, ok := fun.X.(*ast.Ident); ok {
-			// Could be package.Function or var.Method
-			// For simplicity, we'll treat it as package.Function
-			return ident.Name + "." + fun.Sel.Name
+		ident, ok := fun.X.(*ast.Ident)
+		if !ok {
+			// For more complex expressions, use the selector name
+			return currentPkg + "." + fun.Sel.Name, classifyImport(currentPkg, a.modulePath)
 		}
-		// For more complex expressions, use the selector name
-		return currentPkg + "." + fun.Sel.Name
+		if importPath, ok := imports.resolve(ident.Name); ok {
+			return importPath + "." + fun.Sel.Name, classifyImport(importPath, a.modulePath)
+		}
+		// Not a known import alias: most likely a method call on a local
+		// variable or field, e.g. obj.Method().
+		return ident.Name + "." + fun.Sel.Name, classifyImport(currentPkg, a.modulePath)
 
 	default:
-		return ""
+		return "", ""
 	}
 }
 
@@ -215,4 +441,3 @@ func (a *Analyzer) getTypeName(expr ast.Expr) string {
 		return "Unknown"
 	}
 }
-
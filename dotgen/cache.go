@@ -0,0 +1,110 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+)
+
+// analyzerVersion invalidates every cache entry when the AST extraction
+// logic changes in a way that would make old entries wrong (e.g. a new
+// directive, a changed naming scheme). Bump it alongside such changes.
+const analyzerVersion = "3"
+
+// fileResult is everything parseFile extracts from a single file. It is
+// also the unit of work handed between the worker pool and the merging
+// goroutine in analyzeFiles, and the shape persisted per-file in the
+// on-disk cache.
+type fileResult struct {
+	Path      string
+	Package   string
+	Functions []*Function
+	Calls     []Call
+	Ignored   []string
+	ModTime   time.Time
+	Size      int64
+}
+
+func (r *fileResult) cacheEntry() cacheEntry {
+	return cacheEntry{
+		ModTime:   r.ModTime,
+		Size:      r.Size,
+		Version:   analyzerVersion,
+		Package:   r.Package,
+		Functions: r.Functions,
+		Calls:     r.Calls,
+		Ignored:   r.Ignored,
+	}
+}
+
+// cacheEntry is the on-disk record for a single source file: a content
+// fingerprint (mtime + size) plus everything parseFile extracted from
+// it, so an unchanged file can be restored without re-parsing.
+type cacheEntry struct {
+	ModTime   time.Time   `json:"mod_time"`
+	Size      int64       `json:"size"`
+	Version   string      `json:"version"`
+	Package   string      `json:"package"`
+	Functions []*Function `json:"functions"`
+	Calls     []Call      `json:"calls"`
+	Ignored   []string    `json:"ignored"`
+}
+
+func (e cacheEntry) toResult(path string) *fileResult {
+	return &fileResult{
+		Path:      path,
+		Package:   e.Package,
+		Functions: e.Functions,
+		Calls:     e.Calls,
+		Ignored:   e.Ignored,
+		ModTime:   e.ModTime,
+		Size:      e.Size,
+	}
+}
+
+// fileCache maps a file's absolute path to its cached analysis result.
+type fileCache map[string]cacheEntry
+
+// loadCache reads the cache file at path, keyed by file path + mtime +
+// size + analyzerVersion. A missing or corrupt cache file is treated as
+// empty rather than an error, since the cache is purely an optimization.
+func loadCache(path string) fileCache {
+	cache := make(fileCache)
+	if path == "" {
+		return cache
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cache
+	}
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return make(fileCache)
+	}
+	return cache
+}
+
+// saveCache writes cache to path as JSON.
+func saveCache(path string, cache fileCache) error {
+	if path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(cache, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// fresh reports whether the cache already holds an up-to-date entry for
+// path, given its current mtime/size and the running analyzer version.
+func (c fileCache) fresh(path string, info os.FileInfo) (cacheEntry, bool) {
+	entry, ok := c[path]
+	if !ok || entry.Version != analyzerVersion {
+		return cacheEntry{}, false
+	}
+	if !entry.ModTime.Equal(info.ModTime()) || entry.Size != info.Size() {
+		return cacheEntry{}, false
+	}
+	return entry, true
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestCacheIsScopeIndependent guards against a bug where the on-disk
+// cache stored calls already filtered by whatever -scope the run that
+// wrote the cache entry used, since the cache key (file path + mtime +
+// size + analyzerVersion) doesn't account for -scope at all. A later run
+// with a broader -scope over an unchanged file would reuse that
+// over-filtered entry instead of re-extracting, permanently losing
+// calls until the cache file was deleted.
+func TestCacheIsScopeIndependent(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/cachetest\n\ngo 1.21\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	src := `package main
+
+import "fmt"
+
+func main() {
+	fmt.Println("hi")
+}
+`
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cachePath := filepath.Join(dir, ".cache.json")
+
+	narrow := NewAnalyzer(dir, "", false, AlgoAST, 1, cachePath, ScopeModule)
+	cg, err := narrow.Analyze()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cg.Calls) != 0 {
+		t.Fatalf("-scope=module: expected the fmt.Println call to be filtered out, got %d calls", len(cg.Calls))
+	}
+
+	broad := NewAnalyzer(dir, "", false, AlgoAST, 1, cachePath, ScopeAll)
+	cg, err = broad.Analyze()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cg.Calls) != 1 {
+		t.Fatalf("-scope=all after a -scope=module run populated the cache: expected 1 call (fmt.Println) to be restored from the unfiltered cache entry, got %d", len(cg.Calls))
+	}
+}
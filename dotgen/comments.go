@@ -0,0 +1,55 @@
+package main
+
+import (
+	"go/ast"
+	"strings"
+)
+
+// directivePrefix marks a comment line as a callgrapher directive rather
+// than prose documentation, e.g. "//callgraph:group=http".
+const directivePrefix = "callgraph:"
+
+// annotations holds the doc text and //callgraph: directives parsed from
+// a function's leading comments.
+type annotations struct {
+	Doc        string
+	Group      string
+	Color      string
+	Entrypoint bool
+	Ignore     bool
+}
+
+// parseAnnotations scans the comment groups associated with a node (as
+// reported by an ast.CommentMap) for //callgraph: directives, and
+// collects the remaining lines as ordinary doc text.
+func parseAnnotations(groups []*ast.CommentGroup) annotations {
+	var ann annotations
+	var doc []string
+
+	for _, group := range groups {
+		for _, c := range group.List {
+			line := strings.TrimSpace(strings.TrimPrefix(c.Text, "//"))
+			directive, ok := strings.CutPrefix(line, directivePrefix)
+			if !ok {
+				if line != "" {
+					doc = append(doc, line)
+				}
+				continue
+			}
+
+			switch {
+			case directive == "entrypoint":
+				ann.Entrypoint = true
+			case directive == "ignore":
+				ann.Ignore = true
+			case strings.HasPrefix(directive, "group="):
+				ann.Group = strings.TrimPrefix(directive, "group=")
+			case strings.HasPrefix(directive, "color="):
+				ann.Color = strings.TrimPrefix(directive, "color=")
+			}
+		}
+	}
+
+	ann.Doc = strings.TrimSpace(strings.Join(doc, "\n"))
+	return ann
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"go/ast"
+	"strconv"
+	"strings"
+)
+
+// importTable maps the identifier a package is referred to by in one
+// file - its alias, or the default name derived from its import path -
+// to that package's canonical import path. It replaces the old
+// getCallTarget behavior of treating the bare identifier in
+// "ident.Sel()" as the package name, which conflated the local alias
+// with the real import path and collided whenever two imports share an
+// alias (e.g. two different "utils" packages).
+type importTable struct {
+	aliases map[string]string
+
+	// dots holds dot-imported packages. Calls into them appear as bare
+	// identifiers rather than selectors, which the simple AST heuristic
+	// in getCallTarget can't disambiguate from a same-package call
+	// without full type information; -algo=cha/rta/vta (ssa_analyzer.go)
+	// resolves those correctly instead.
+	dots []string
+}
+
+// buildImportTable walks file.Imports, honoring renamed and dot imports.
+func buildImportTable(file *ast.File) importTable {
+	t := importTable{aliases: make(map[string]string)}
+
+	for _, imp := range file.Imports {
+		path, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			continue
+		}
+
+		switch {
+		case imp.Name == nil:
+			t.aliases[defaultImportName(path)] = path
+		case imp.Name.Name == "_":
+			// Blank import: never referenced by identifier.
+		case imp.Name.Name == ".":
+			t.dots = append(t.dots, path)
+		default:
+			t.aliases[imp.Name.Name] = path
+		}
+	}
+
+	return t
+}
+
+// resolve returns the canonical import path for an identifier as it's
+// used as a selector receiver in this file, e.g. the "utils" in
+// "utils.ReadFile()".
+func (t importTable) resolve(ident string) (path string, ok bool) {
+	path, ok = t.aliases[ident]
+	return path, ok
+}
+
+// defaultImportName guesses the identifier an unaliased import is
+// referred to by: the last element of its import path.
+func defaultImportName(path string) string {
+	if i := strings.LastIndexByte(path, '/'); i >= 0 {
+		return path[i+1:]
+	}
+	return path
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+)
+
+func parseTestFile(t *testing.T, src string) *ast.File {
+	t.Helper()
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "test.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("parsing test source: %v", err)
+	}
+	return file
+}
+
+func TestBuildImportTableAliasesAndDots(t *testing.T) {
+	file := parseTestFile(t, `package main
+
+import (
+	"fmt"
+	u1 "example.com/scopetest/pkgone"
+	"example.com/scopetest/pkgtwo/utils"
+	. "example.com/scopetest/dotted"
+	_ "example.com/scopetest/blank"
+)
+
+func main() {}
+`)
+
+	table := buildImportTable(file)
+
+	tests := []struct {
+		ident    string
+		wantPath string
+		wantOK   bool
+	}{
+		{"fmt", "fmt", true},
+		{"u1", "example.com/scopetest/pkgone", true},
+		{"utils", "example.com/scopetest/pkgtwo/utils", true},
+		{"blank", "", false},
+		{"dotted", "", false}, // dot imports aren't referenced by identifier
+	}
+	for _, tt := range tests {
+		path, ok := table.resolve(tt.ident)
+		if ok != tt.wantOK || path != tt.wantPath {
+			t.Errorf("resolve(%q) = (%q, %v), want (%q, %v)", tt.ident, path, ok, tt.wantPath, tt.wantOK)
+		}
+	}
+
+	if len(table.dots) != 1 || table.dots[0] != "example.com/scopetest/dotted" {
+		t.Errorf("dots = %v, want [example.com/scopetest/dotted]", table.dots)
+	}
+}
+
+// TestGetCallTargetResolvesAliasCollision is the regression case chunk0-6
+// was written for: two packages both locally named "utils" (one via
+// alias, one via its default name) must resolve to their own distinct
+// canonical import paths rather than colliding on the bare identifier.
+func TestGetCallTargetResolvesAliasCollision(t *testing.T) {
+	file := parseTestFile(t, `package main
+
+import (
+	u1 "example.com/scopetest/pkgone"
+	"example.com/scopetest/pkgtwo/utils"
+)
+
+func main() {
+	u1.ReadFile()
+	utils.ReadFile()
+}
+`)
+	imports := buildImportTable(file)
+
+	a := &Analyzer{modulePath: "example.com/scopetest", scope: ScopeAll}
+
+	var calls []*ast.CallExpr
+	ast.Inspect(file, func(n ast.Node) bool {
+		if call, ok := n.(*ast.CallExpr); ok {
+			calls = append(calls, call)
+		}
+		return true
+	})
+	if len(calls) != 2 {
+		t.Fatalf("expected 2 call expressions, got %d", len(calls))
+	}
+
+	target, scope := a.getCallTarget(calls[0], "example.com/scopetest", imports)
+	if want := "example.com/scopetest/pkgone.ReadFile"; target != want {
+		t.Errorf("first call target = %q, want %q", target, want)
+	}
+	if scope != ScopeModule {
+		t.Errorf("first call scope = %q, want %q", scope, ScopeModule)
+	}
+
+	target, scope = a.getCallTarget(calls[1], "example.com/scopetest", imports)
+	if want := "example.com/scopetest/pkgtwo/utils.ReadFile"; target != want {
+		t.Errorf("second call target = %q, want %q", target, want)
+	}
+	if scope != ScopeModule {
+		t.Errorf("second call scope = %q, want %q", scope, ScopeModule)
+	}
+}
@@ -5,16 +5,41 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strings"
 )
 
+// splitList parses a comma-separated flag value into a trimmed,
+// non-empty list of items.
+func splitList(s string) []string {
+	if s == "" {
+		return nil
+	}
+	parts := strings.Split(s, ",")
+	items := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			items = append(items, p)
+		}
+	}
+	return items
+}
+
 func main() {
 	// Define command-line flags
 	var (
-		inputPath  = flag.String("path", ".", "Path to Go codebase to analyze")
-		outputFile = flag.String("output", "callgraph.dot", "Output DOT file path")
-		maxDepth   = flag.Int("depth", -1, "Maximum call depth to analyze (-1 for unlimited)")
-		verbose    = flag.Bool("verbose", false, "Enable verbose output")
+		inputPath   = flag.String("path", ".", "Path to Go codebase to analyze")
+		outputFile  = flag.String("output", "callgraph.dot", "Output file path (written in the -format format)")
+		maxDepth    = flag.Int("depth", -1, "Maximum call depth from -root/-sink to include (-1 for unlimited); has no effect without -root or -sink")
+		verbose     = flag.Bool("verbose", false, "Enable verbose output")
 		excludeDirs = flag.String("exclude", "", "Comma-separated list of directories to exclude (e.g., vendor,testdata)")
+		algoFlag    = flag.String("algo", "ast", "Call resolution algorithm: ast|static|cha|rta|vta")
+		rootFlag    = flag.String("root", "", "Comma-separated list of root functions; prune the graph to what they transitively call")
+		sinkFlag    = flag.String("sink", "", "Comma-separated list of sink functions; prune the graph to what transitively calls them")
+		jobs        = flag.Int("jobs", runtime.NumCPU(), "Number of files to parse concurrently (ast algorithm only)")
+		cachePath   = flag.String("cache", ".callgrapher-cache.json", "Path to the incremental analysis cache (\"\" disables caching)")
+		format      = flag.String("format", "dot", "Output format: dot|json|graphml|mermaid|html")
+		scopeFlag   = flag.String("scope", "all", "Filter recorded edges by callee scope: module|stdlib|all")
 	)
 
 	flag.Usage = func() {
@@ -28,6 +53,28 @@ func main() {
 
 	flag.Parse()
 
+	algo, err := ParseAlgorithm(*algoFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	renderer, err := NewRenderer(*format)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	scope, err := ParseScope(*scopeFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if *maxDepth >= 0 && *rootFlag == "" && *sinkFlag == "" {
+		fmt.Fprintf(os.Stderr, "Warning: -depth has no effect without -root or -sink; the full graph will be emitted\n")
+	}
+
 	// Validate input path
 	absPath, err := filepath.Abs(*inputPath)
 	if err != nil {
@@ -43,13 +90,14 @@ func main() {
 	if *verbose {
 		fmt.Printf("Analyzing Go codebase at: %s\n", absPath)
 		fmt.Printf("Output file: %s\n", *outputFile)
-		if *maxDepth >= 0 {
-			fmt.Printf("Max depth: %d\n", *maxDepth)
+		fmt.Printf("Algorithm: %s\n", algo)
+		if *maxDepth >= 0 && (*rootFlag != "" || *sinkFlag != "") {
+			fmt.Printf("Max depth from -root/-sink: %d\n", *maxDepth)
 		}
 	}
 
 	// Create analyzer
-	analyzer := NewAnalyzer(absPath, *excludeDirs, *verbose)
+	analyzer := NewAnalyzer(absPath, *excludeDirs, *verbose, algo, *jobs, *cachePath, scope)
 
 	// Analyze the codebase
 	if *verbose {
@@ -63,22 +111,44 @@ func main() {
 	}
 
 	if *verbose {
-		fmt.Printf("Found %d functions and %d calls\n", 
+		fmt.Printf("Found %d functions and %d calls\n",
 			len(callGraph.Functions), len(callGraph.Calls))
 	}
 
-	// Generate DOT file
-	if *verbose {
-		fmt.Println("Generating DOT file...")
+	// Prune to the functions reachable from -root and/or able to reach
+	// -sink, if requested.
+	if roots := splitList(*rootFlag); len(roots) > 0 {
+		if unmatched := callGraph.UnmatchedSeeds(roots); len(unmatched) > 0 {
+			fmt.Fprintf(os.Stderr, "Warning: -root value(s) matched no function: %s\n", strings.Join(unmatched, ", "))
+		}
+		callGraph = callGraph.Reachable(roots, *maxDepth)
+	}
+	if sinks := splitList(*sinkFlag); len(sinks) > 0 {
+		if unmatched := callGraph.UnmatchedSeeds(sinks); len(unmatched) > 0 {
+			fmt.Fprintf(os.Stderr, "Warning: -sink value(s) matched no function: %s\n", strings.Join(unmatched, ", "))
+		}
+		callGraph = callGraph.Backward(sinks, *maxDepth)
+	}
+
+	if *verbose && (*rootFlag != "" || *sinkFlag != "") {
+		fmt.Printf("After pruning: %d functions and %d calls\n",
+			len(callGraph.Functions), len(callGraph.Calls))
 	}
 
-	generator := NewDOTGenerator(callGraph, *maxDepth)
-	dotContent := generator.Generate()
+	// Render the output file
+	if *verbose {
+		fmt.Printf("Rendering %s output...\n", *format)
+	}
 
-	// Write to file
-	err = os.WriteFile(*outputFile, []byte(dotContent), 0644)
+	out, err := os.Create(*outputFile)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error writing output file: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Error creating output file: %v\n", err)
+		os.Exit(1)
+	}
+	defer out.Close()
+
+	if err := renderer.Render(callGraph, out); err != nil {
+		fmt.Fprintf(os.Stderr, "Error rendering output file: %v\n", err)
 		os.Exit(1)
 	}
 
@@ -88,4 +158,3 @@ func main() {
 		fmt.Printf("  Calls: %d\n", len(callGraph.Calls))
 	}
 }
-
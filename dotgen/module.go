@@ -0,0 +1,81 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+)
+
+// findModule walks upward from dir looking for a go.mod and returns the
+// module path it declares and the directory it lives in. Both are ""
+// if no go.mod is found, in which case callers fall back to the
+// package-name-only behavior this analyzer had before import
+// resolution was added.
+func findModule(dir string) (modulePath, moduleDir string) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", ""
+	}
+	for {
+		data, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+		if err == nil {
+			return modfile.ModulePath(data), dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", ""
+		}
+		dir = parent
+	}
+}
+
+// importPathOf returns the canonical import path of the package
+// containing fileDir, given the module path and directory discovered
+// by findModule. It returns "" if fileDir isn't inside moduleDir.
+func importPathOf(fileDir, modulePath, moduleDir string) string {
+	if modulePath == "" || moduleDir == "" {
+		return ""
+	}
+	rel, err := filepath.Rel(moduleDir, fileDir)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return ""
+	}
+	if rel == "." {
+		return modulePath
+	}
+	return modulePath + "/" + filepath.ToSlash(rel)
+}
+
+// isStdlib reports whether an import path looks like it belongs to the
+// standard library: its first path element has no dot. This is the
+// same heuristic goimports and friends use, since third-party paths
+// always carry a domain, e.g. golang.org/x/tools.
+func isStdlib(importPath string) bool {
+	first := importPath
+	if i := strings.IndexByte(importPath, '/'); i >= 0 {
+		first = importPath[:i]
+	}
+	return !strings.Contains(first, ".")
+}
+
+// classifyImport buckets importPath relative to modulePath into a Scope.
+// modulePath == "" means findModule couldn't locate a go.mod above the
+// analyzed path, in which case - per NewAnalyzer's doc comment - every
+// call is treated as module-internal, since without a module path there
+// is no way to tell a same-package call (where importPath is just the
+// bare package name, e.g. "main") apart from a real stdlib import.
+func classifyImport(importPath, modulePath string) Scope {
+	if modulePath == "" {
+		return ScopeModule
+	}
+	switch {
+	case isStdlib(importPath):
+		return ScopeStdlib
+	case importPath == modulePath || strings.HasPrefix(importPath, modulePath+"/"):
+		return ScopeModule
+	default:
+		return ScopeExternal
+	}
+}
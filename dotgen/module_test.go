@@ -0,0 +1,53 @@
+package main
+
+import "testing"
+
+func TestClassifyImportNoGoModTreatsEverythingAsModule(t *testing.T) {
+	// Per NewAnalyzer's doc comment, without a discoverable go.mod every
+	// call is module-internal - including same-package calls, whose
+	// importPath is just a bare package name like "main", and even
+	// selector calls resolved to a real stdlib import like "fmt", since
+	// there's no module path to tell them apart from local code.
+	for _, importPath := range []string{"main", "utils", "fmt", "golang.org/x/tools"} {
+		if got := classifyImport(importPath, ""); got != ScopeModule {
+			t.Errorf("classifyImport(%q, \"\") = %q, want %q", importPath, got, ScopeModule)
+		}
+	}
+}
+
+func TestClassifyImportWithGoMod(t *testing.T) {
+	const modulePath = "example.com/mod"
+
+	tests := []struct {
+		importPath string
+		want       Scope
+	}{
+		{"fmt", ScopeStdlib},
+		{"encoding/json", ScopeStdlib},
+		{modulePath, ScopeModule},
+		{modulePath + "/pkg", ScopeModule},
+		{"golang.org/x/tools/go/ssa", ScopeExternal},
+	}
+	for _, tt := range tests {
+		if got := classifyImport(tt.importPath, modulePath); got != tt.want {
+			t.Errorf("classifyImport(%q, %q) = %q, want %q", tt.importPath, modulePath, got, tt.want)
+		}
+	}
+}
+
+func TestIsStdlib(t *testing.T) {
+	tests := []struct {
+		importPath string
+		want       bool
+	}{
+		{"fmt", true},
+		{"encoding/json", true},
+		{"golang.org/x/tools", false},
+		{"example.com/mod/pkg", false},
+	}
+	for _, tt := range tests {
+		if got := isStdlib(tt.importPath); got != tt.want {
+			t.Errorf("isStdlib(%q) = %v, want %v", tt.importPath, got, tt.want)
+		}
+	}
+}
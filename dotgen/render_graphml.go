@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"html"
+	"io"
+)
+
+// GraphMLRenderer renders a CallGraph as GraphML, for import into tools
+// like yEd or Gephi.
+type GraphMLRenderer struct{}
+
+func (GraphMLRenderer) Render(cg *CallGraph, w io.Writer) error {
+	var err error
+	write := func(format string, args ...any) {
+		if err != nil {
+			return
+		}
+		_, err = fmt.Fprintf(w, format, args...)
+	}
+
+	write("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n")
+	write("<graphml xmlns=\"http://graphml.graphdrawing.org/xmlns\">\n")
+	write("  <key id=\"label\" for=\"node\" attr.name=\"label\" attr.type=\"string\"/>\n")
+	write("  <key id=\"package\" for=\"node\" attr.name=\"package\" attr.type=\"string\"/>\n")
+	write("  <key id=\"algo\" for=\"edge\" attr.name=\"algo\" attr.type=\"string\"/>\n")
+	write("  <key id=\"scope\" for=\"edge\" attr.name=\"scope\" attr.type=\"string\"/>\n")
+	write("  <graph id=\"callgraph\" edgedefault=\"directed\">\n")
+
+	for _, name := range sortedNodes(cg) {
+		fn := cg.Functions[name]
+		write("    <node id=\"%s\">\n", html.EscapeString(name))
+		write("      <data key=\"label\">%s</data>\n", html.EscapeString(fn.Name))
+		write("      <data key=\"package\">%s</data>\n", html.EscapeString(fn.Package))
+		write("    </node>\n")
+	}
+
+	for i, edge := range liveEdges(cg) {
+		write("    <edge id=\"e%d\" source=\"%s\" target=\"%s\">\n", i, html.EscapeString(edge.From), html.EscapeString(edge.To))
+		write("      <data key=\"algo\">%s</data>\n", html.EscapeString(string(edge.Algo)))
+		write("      <data key=\"scope\">%s</data>\n", html.EscapeString(string(edge.Scope)))
+		write("    </edge>\n")
+	}
+
+	write("  </graph>\n")
+	write("</graphml>\n")
+
+	return err
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// HTMLRenderer renders a CallGraph as a single self-contained HTML page:
+// the graph embedded as JSON plus a small vanilla-JS viewer (search box,
+// depth slider, click-to-focus) built on an inline force layout. It
+// needs no server and no Graphviz install, so it's easy to drop into
+// docs sites or share directly.
+type HTMLRenderer struct{}
+
+func (HTMLRenderer) Render(cg *CallGraph, w io.Writer) error {
+	data, err := json.Marshal(toJSONGraph(cg))
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, htmlTemplate, data)
+	return err
+}
+
+// htmlTemplate embeds the graph JSON as %s and a minimal viewer. The
+// layout is a plain force-directed simulation written by hand rather
+// than pulled in as a script dependency, so the page loads nothing
+// from outside itself.
+const htmlTemplate = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Callgraph</title>
+<style>
+  body { margin: 0; font-family: sans-serif; }
+  #toolbar { position: fixed; top: 0; left: 0; right: 0; padding: 8px; background: #222; color: #eee; display: flex; gap: 12px; align-items: center; z-index: 1; }
+  #toolbar input, #toolbar label { color: #eee; }
+  svg { width: 100%%; height: 100vh; }
+  .node circle { fill: #4f8; stroke: #222; cursor: pointer; }
+  .node.dim circle { fill: #888; opacity: 0.3; }
+  .node text { font-size: 10px; pointer-events: none; }
+  .node.dim text { opacity: 0.2; }
+  .link { stroke: #999; stroke-opacity: 0.6; }
+  .link.dim { opacity: 0.1; }
+</style>
+</head>
+<body>
+<div id="toolbar">
+  <input id="search" placeholder="search functions...">
+  <label>depth <input id="depth" type="range" min="1" max="10" value="10"></label>
+  <span id="count"></span>
+</div>
+<svg></svg>
+<script>
+const graph = %s;
+const svg = document.querySelector("svg");
+const width = window.innerWidth, height = window.innerHeight;
+svg.setAttribute("viewBox", "0 0 " + width + " " + height);
+
+const byId = {};
+graph.nodes.forEach(function(n, i) {
+  n.x = width/2 + 200*Math.cos(i); n.y = height/2 + 200*Math.sin(i);
+  byId[n.id] = n;
+});
+
+const adjacency = {};
+graph.edges.forEach(function(e) {
+  (adjacency[e.from] = adjacency[e.from] || []).push(e.to);
+  (adjacency[e.to] = adjacency[e.to] || []).push(e.from);
+});
+
+const svgNS = "http://www.w3.org/2000/svg";
+function el(tag, attrs) {
+  const e = document.createElementNS(svgNS, tag);
+  for (const k in attrs) e.setAttribute(k, attrs[k]);
+  return e;
+}
+
+const linkEls = graph.edges.map(function(e) {
+  const l = el("line", {class: "link"});
+  svg.appendChild(l);
+  return {el: l, data: e};
+});
+
+const nodeEls = graph.nodes.map(function(n) {
+  const g = el("g", {class: "node"});
+  const r = n.entrypoint ? 8 : 5;
+  g.appendChild(el("circle", {r: r}));
+  const t = el("text", {x: r + 2, y: 3});
+  t.textContent = n.label;
+  g.appendChild(t);
+  svg.appendChild(g);
+  g.addEventListener("click", function() { focus(n.id); });
+  return {el: g, data: n};
+});
+
+function tick() {
+  // Simple repulsion + spring layout, run a fixed number of iterations
+  // up front rather than animating every frame.
+  nodeEls.forEach(function(a) {
+    nodeEls.forEach(function(b) {
+      if (a === b) return;
+      const dx = a.data.x - b.data.x, dy = a.data.y - b.data.y;
+      const dist = Math.max(Math.sqrt(dx*dx + dy*dy), 1);
+      const force = 400 / (dist * dist);
+      a.data.x += (dx / dist) * force;
+      a.data.y += (dy / dist) * force;
+    });
+  });
+  graph.edges.forEach(function(e) {
+    const a = byId[e.from], b = byId[e.to];
+    if (!a || !b) return;
+    const dx = b.x - a.x, dy = b.y - a.y;
+    a.x += dx * 0.01; a.y += dy * 0.01;
+    b.x -= dx * 0.01; b.y -= dy * 0.01;
+  });
+}
+for (let i = 0; i < 300; i++) tick();
+
+function render() {
+  nodeEls.forEach(function(n) {
+    n.el.setAttribute("transform", "translate(" + n.data.x + "," + n.data.y + ")");
+  });
+  linkEls.forEach(function(l) {
+    const a = byId[l.data.from], b = byId[l.data.to];
+    if (!a || !b) return;
+    l.el.setAttribute("x1", a.x); l.el.setAttribute("y1", a.y);
+    l.el.setAttribute("x2", b.x); l.el.setAttribute("y2", b.y);
+  });
+}
+render();
+
+function reachable(id, depth) {
+  const seen = {}; seen[id] = 0;
+  let frontier = [id];
+  for (let d = 0; d < depth && frontier.length; d++) {
+    const next = [];
+    frontier.forEach(function(cur) {
+      (adjacency[cur] || []).forEach(function(nbr) {
+        if (!(nbr in seen)) { seen[nbr] = d + 1; next.push(nbr); }
+      });
+    });
+    frontier = next;
+  }
+  return seen;
+}
+
+let focused = null;
+function focus(id) {
+  focused = id;
+  applyFilters();
+}
+
+function applyFilters() {
+  const query = document.getElementById("search").value.toLowerCase();
+  const depth = parseInt(document.getElementById("depth").value, 10);
+  const within = focused ? reachable(focused, depth) : null;
+
+  let shown = 0;
+  nodeEls.forEach(function(n) {
+    const matchesSearch = !query || n.data.label.toLowerCase().indexOf(query) !== -1;
+    const matchesFocus = !within || (n.data.id in within);
+    const visible = matchesSearch && matchesFocus;
+    n.el.classList.toggle("dim", !visible);
+    if (visible) shown++;
+  });
+  linkEls.forEach(function(l) {
+    const visible = (!within || (l.data.from in within && l.data.to in within));
+    l.el.classList.toggle("dim", !visible);
+  });
+  document.getElementById("count").textContent = shown + " / " + graph.nodes.length + " functions";
+}
+
+document.getElementById("search").addEventListener("input", applyFilters);
+document.getElementById("depth").addEventListener("input", applyFilters);
+applyFilters();
+</script>
+</body>
+</html>
+`
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// jsonNode and jsonEdge are the shapes d3.js and cytoscape.js both
+// expect for a generic node-link graph.
+type jsonNode struct {
+	ID         string `json:"id"`
+	Label      string `json:"label"`
+	Package    string `json:"package"`
+	Group      string `json:"group,omitempty"`
+	Entrypoint bool   `json:"entrypoint,omitempty"`
+}
+
+type jsonEdge struct {
+	From  string    `json:"from"`
+	To    string    `json:"to"`
+	Algo  Algorithm `json:"algo,omitempty"`
+	Scope Scope     `json:"scope,omitempty"`
+}
+
+type jsonGraph struct {
+	Nodes []jsonNode `json:"nodes"`
+	Edges []jsonEdge `json:"edges"`
+}
+
+func toJSONGraph(cg *CallGraph) jsonGraph {
+	g := jsonGraph{}
+	for _, name := range sortedNodes(cg) {
+		fn := cg.Functions[name]
+		g.Nodes = append(g.Nodes, jsonNode{
+			ID:         name,
+			Label:      fn.Name,
+			Package:    fn.Package,
+			Group:      fn.Group,
+			Entrypoint: fn.Entrypoint,
+		})
+	}
+	for _, edge := range liveEdges(cg) {
+		g.Edges = append(g.Edges, jsonEdge{From: edge.From, To: edge.To, Algo: edge.Algo, Scope: edge.Scope})
+	}
+	return g
+}
+
+// JSONRenderer renders a CallGraph as a {nodes, edges} document, the
+// format d3.js and cytoscape.js both consume directly.
+type JSONRenderer struct{}
+
+func (JSONRenderer) Render(cg *CallGraph, w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(toJSONGraph(cg))
+}
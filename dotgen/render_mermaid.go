@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+)
+
+// mermaidID replaces each character Mermaid node IDs can't contain with
+// an underscore.
+var mermaidIDDisallowed = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+func mermaidID(name string) string {
+	return mermaidIDDisallowed.ReplaceAllString(name, "_")
+}
+
+// MermaidRenderer renders a CallGraph as a Mermaid "flowchart TD"
+// document, suitable for embedding directly in Markdown docs that
+// support Mermaid (GitHub, GitLab, many doc sites).
+type MermaidRenderer struct{}
+
+func (MermaidRenderer) Render(cg *CallGraph, w io.Writer) error {
+	var err error
+	write := func(format string, args ...any) {
+		if err != nil {
+			return
+		}
+		_, err = fmt.Fprintf(w, format, args...)
+	}
+
+	write("flowchart TD\n")
+
+	for _, name := range sortedNodes(cg) {
+		fn := cg.Functions[name]
+		id := mermaidID(name)
+		if fn.Entrypoint {
+			write("  %s([%q])\n", id, fn.Name)
+		} else {
+			write("  %s[%q]\n", id, fn.Name)
+		}
+	}
+
+	for _, edge := range liveEdges(cg) {
+		arrow := "-->"
+		if edge.Algo == AlgoCHA || edge.Algo == AlgoRTA || edge.Algo == AlgoVTA {
+			arrow = "-.->"
+		}
+		write("  %s %s %s\n", mermaidID(edge.From), arrow, mermaidID(edge.To))
+	}
+
+	return err
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+// Renderer writes a CallGraph to w in some output format. main prunes
+// the graph with -root/-sink/-depth (see slice.go) before rendering, so
+// a Renderer never needs to think about depth or roots/sinks itself -
+// it only has to walk the Functions/Calls it's handed.
+type Renderer interface {
+	Render(cg *CallGraph, w io.Writer) error
+}
+
+// renderers maps a -format value to its constructor.
+var renderers = map[string]func() Renderer{
+	"dot":     func() Renderer { return &DOTRenderer{} },
+	"json":    func() Renderer { return &JSONRenderer{} },
+	"graphml": func() Renderer { return &GraphMLRenderer{} },
+	"mermaid": func() Renderer { return &MermaidRenderer{} },
+	"html":    func() Renderer { return &HTMLRenderer{} },
+}
+
+// NewRenderer looks up the Renderer registered for format.
+func NewRenderer(format string) (Renderer, error) {
+	ctor, ok := renderers[format]
+	if !ok {
+		return nil, fmt.Errorf("unknown -format %q (want dot, json, graphml, mermaid, or html)", format)
+	}
+	return ctor(), nil
+}
+
+// graphEdge is a call edge with its ignored endpoints already dropped,
+// shared by every renderer.
+type graphEdge struct {
+	From, To string
+	Algo     Algorithm
+	Scope    Scope
+}
+
+// sortedNodes and liveEdges give every renderer the same deterministic,
+// ignore-filtered view of the graph, so a diff between formats reflects
+// the format, not iteration order.
+func sortedNodes(cg *CallGraph) []string {
+	names := make([]string, 0, len(cg.Functions))
+	for name := range cg.Functions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func liveEdges(cg *CallGraph) []graphEdge {
+	edges := make([]graphEdge, 0, len(cg.Calls))
+	for _, call := range cg.Calls {
+		if cg.Ignored[call.From] || cg.Ignored[call.To] {
+			continue
+		}
+		edges = append(edges, graphEdge{From: call.From, To: call.To, Algo: call.Algo, Scope: call.Scope})
+	}
+	return edges
+}
@@ -0,0 +1,39 @@
+package main
+
+import "fmt"
+
+// Scope classifies a call edge by where its callee's package lives
+// relative to the module being analyzed.
+type Scope string
+
+const (
+	ScopeAll      Scope = "all"      // not a real classification; only valid as a -scope filter value
+	ScopeModule   Scope = "module"   // callee is declared inside the analyzed module
+	ScopeStdlib   Scope = "stdlib"   // callee is declared in the Go standard library
+	ScopeExternal Scope = "external" // callee is a third-party dependency
+)
+
+// ParseScope validates a user-supplied -scope value.
+func ParseScope(s string) (Scope, error) {
+	switch Scope(s) {
+	case ScopeAll, ScopeModule, ScopeStdlib:
+		return Scope(s), nil
+	default:
+		return "", fmt.Errorf("unknown -scope %q (want module, all, or stdlib)", s)
+	}
+}
+
+// allows reports whether a call classified as edgeScope should be kept
+// under the -scope filter. "module" keeps only in-module calls,
+// "stdlib" additionally keeps calls into the standard library, and
+// "all" keeps everything, including third-party dependencies.
+func (filter Scope) allows(edgeScope Scope) bool {
+	switch filter {
+	case ScopeModule:
+		return edgeScope == ScopeModule
+	case ScopeStdlib:
+		return edgeScope == ScopeModule || edgeScope == ScopeStdlib
+	default: // ScopeAll, or unset
+		return true
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+// Reachable returns a new CallGraph containing only the functions
+// reachable forward from roots (i.e. what they transitively call), and
+// the calls between them. A maxDepth < 0 means unlimited depth; a
+// maxDepth of 0 returns just the roots themselves with no edges.
+func (cg *CallGraph) Reachable(roots []string, maxDepth int) *CallGraph {
+	return cg.slice(roots, maxDepth, func(call Call) (string, string) {
+		return call.From, call.To
+	})
+}
+
+// Backward returns a new CallGraph containing only the functions that
+// can reach sinks (i.e. their direct and transitive callers), and the
+// calls between them. A maxDepth < 0 means unlimited depth; a maxDepth
+// of 0 returns just the sinks themselves with no edges.
+func (cg *CallGraph) Backward(sinks []string, maxDepth int) *CallGraph {
+	return cg.slice(sinks, maxDepth, func(call Call) (string, string) {
+		return call.To, call.From
+	})
+}
+
+// UnmatchedSeeds returns the subset of seeds that name no function in
+// cg. Reachable and Backward silently ignore such seeds, which makes a
+// typo'd or stale -root/-sink indistinguishable from "nothing is
+// reachable" - callers should check this and warn before pruning.
+func (cg *CallGraph) UnmatchedSeeds(seeds []string) []string {
+	var unmatched []string
+	for _, seed := range seeds {
+		if _, ok := cg.Functions[seed]; !ok {
+			unmatched = append(unmatched, seed)
+		}
+	}
+	return unmatched
+}
+
+// slice performs a breadth-first walk from seeds up to maxDepth hops,
+// following edges as directed by next (From->To for Reachable, To->From
+// for Backward), and returns the functions it reached and the edges it
+// followed. Nodes at maxDepth are not expanded, so edges leaving them
+// are left out even when both ends were reached.
+func (cg *CallGraph) slice(seeds []string, maxDepth int, next func(Call) (string, string)) *CallGraph {
+	adjacency := make(map[string][]Call)
+	for _, call := range cg.Calls {
+		from, _ := next(call)
+		adjacency[from] = append(adjacency[from], call)
+	}
+
+	depth := make(map[string]int)
+	queue := make([]string, 0, len(seeds))
+	for _, seed := range seeds {
+		if _, ok := cg.Functions[seed]; !ok {
+			continue
+		}
+		if _, seen := depth[seed]; seen {
+			continue
+		}
+		depth[seed] = 0
+		queue = append(queue, seed)
+	}
+
+	result := &CallGraph{
+		Functions: make(map[string]*Function),
+		Calls:     make([]Call, 0),
+		Packages:  make(map[string]bool),
+		Ignored:   cg.Ignored,
+	}
+
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		d := depth[name]
+
+		if maxDepth >= 0 && d >= maxDepth {
+			continue
+		}
+
+		for _, call := range adjacency[name] {
+			_, to := next(call)
+			result.Calls = append(result.Calls, call)
+			if _, seen := depth[to]; !seen {
+				depth[to] = d + 1
+				queue = append(queue, to)
+			}
+		}
+	}
+
+	for name := range depth {
+		if fn, ok := cg.Functions[name]; ok {
+			result.Functions[name] = fn
+			result.Packages[fn.Package] = true
+		}
+	}
+
+	return result
+}
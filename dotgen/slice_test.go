@@ -0,0 +1,115 @@
+package main
+
+import "testing"
+
+// newTestGraph builds a small graph with a cycle and a receiver-qualified
+// method, used by several tests below:
+//
+//	main.main -> pkg.A -> pkg.B -> pkg.A (cycle)
+//	            pkg.A -> pkg.Server.Handle -> pkg.log
+func newTestGraph() *CallGraph {
+	cg := &CallGraph{
+		Functions: make(map[string]*Function),
+		Calls:     make([]Call, 0),
+		Packages:  make(map[string]bool),
+	}
+	for _, name := range []string{"main.main", "pkg.A", "pkg.B", "pkg.Server.Handle", "pkg.log", "pkg.unreachable"} {
+		cg.Functions[name] = &Function{Name: name}
+	}
+	edges := [][2]string{
+		{"main.main", "pkg.A"},
+		{"pkg.A", "pkg.B"},
+		{"pkg.B", "pkg.A"}, // cycle back to pkg.A
+		{"pkg.A", "pkg.Server.Handle"},
+		{"pkg.Server.Handle", "pkg.log"},
+	}
+	for _, e := range edges {
+		cg.Calls = append(cg.Calls, Call{From: e[0], To: e[1]})
+	}
+	return cg
+}
+
+func TestReachableFollowsCycles(t *testing.T) {
+	cg := newTestGraph()
+
+	sliced := cg.Reachable([]string{"main.main"}, -1)
+
+	for _, want := range []string{"main.main", "pkg.A", "pkg.B", "pkg.Server.Handle", "pkg.log"} {
+		if _, ok := sliced.Functions[want]; !ok {
+			t.Errorf("Reachable: expected %q in result, got %v", want, keys(sliced.Functions))
+		}
+	}
+	if _, ok := sliced.Functions["pkg.unreachable"]; ok {
+		t.Errorf("Reachable: did not expect pkg.unreachable in result")
+	}
+}
+
+func TestReachableReceiverQualifiedName(t *testing.T) {
+	cg := newTestGraph()
+
+	sliced := cg.Reachable([]string{"main.main"}, -1)
+
+	if _, ok := sliced.Functions["pkg.Server.Handle"]; !ok {
+		t.Fatalf("Reachable: expected receiver-qualified name pkg.Server.Handle in result")
+	}
+	found := false
+	for _, call := range sliced.Calls {
+		if call.From == "pkg.A" && call.To == "pkg.Server.Handle" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Reachable: expected edge pkg.A -> pkg.Server.Handle in result")
+	}
+}
+
+func TestReachableDepthClamping(t *testing.T) {
+	cg := newTestGraph()
+
+	sliced := cg.Reachable([]string{"main.main"}, 0)
+	if len(sliced.Functions) != 1 || len(sliced.Calls) != 0 {
+		t.Fatalf("Reachable depth=0: expected only the root with no edges, got %d functions, %d calls",
+			len(sliced.Functions), len(sliced.Calls))
+	}
+
+	sliced = cg.Reachable([]string{"main.main"}, 1)
+	if _, ok := sliced.Functions["pkg.A"]; !ok {
+		t.Errorf("Reachable depth=1: expected pkg.A in result")
+	}
+	if _, ok := sliced.Functions["pkg.B"]; ok {
+		t.Errorf("Reachable depth=1: did not expect pkg.B in result (2 hops away)")
+	}
+}
+
+func TestBackwardFollowsCyclesToSink(t *testing.T) {
+	cg := newTestGraph()
+
+	sliced := cg.Backward([]string{"pkg.log"}, -1)
+
+	for _, want := range []string{"pkg.log", "pkg.Server.Handle", "pkg.A", "pkg.B", "main.main"} {
+		if _, ok := sliced.Functions[want]; !ok {
+			t.Errorf("Backward: expected %q in result, got %v", want, keys(sliced.Functions))
+		}
+	}
+}
+
+func TestUnmatchedSeedsReportsTypos(t *testing.T) {
+	cg := newTestGraph()
+
+	unmatched := cg.UnmatchedSeeds([]string{"main.main", "pkg.Typo"})
+	if len(unmatched) != 1 || unmatched[0] != "pkg.Typo" {
+		t.Errorf("UnmatchedSeeds: expected [pkg.Typo], got %v", unmatched)
+	}
+
+	if unmatched := cg.UnmatchedSeeds([]string{"main.main", "pkg.A"}); len(unmatched) != 0 {
+		t.Errorf("UnmatchedSeeds: expected no unmatched seeds, got %v", unmatched)
+	}
+}
+
+func keys(m map[string]*Function) []string {
+	ks := make([]string, 0, len(m))
+	for k := range m {
+		ks = append(ks, k)
+	}
+	return ks
+}
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/callgraph"
+	"golang.org/x/tools/go/callgraph/cha"
+	"golang.org/x/tools/go/callgraph/rta"
+	"golang.org/x/tools/go/callgraph/static"
+	"golang.org/x/tools/go/callgraph/vta"
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/go/ssa"
+	"golang.org/x/tools/go/ssa/ssautil"
+)
+
+// analyzeTyped builds a call graph for every Algorithm other than AlgoAST.
+// It loads a.rootPath with full type information via go/packages, builds
+// an SSA representation of the program, and resolves call targets -
+// including dynamic dispatch through interfaces and function values -
+// with the algorithm the caller selected.
+func (a *Analyzer) analyzeTyped() (*CallGraph, error) {
+	cfg := &packages.Config{
+		Mode: packages.LoadAllSyntax,
+		Dir:  a.rootPath,
+	}
+	pkgs, err := packages.Load(cfg, "./...")
+	if err != nil {
+		return nil, fmt.Errorf("loading packages: %w", err)
+	}
+	if packages.PrintErrors(pkgs) > 0 && a.verbose {
+		fmt.Println("  warning: some packages had type errors; the graph may be incomplete")
+	}
+
+	prog, ssaPkgs := ssautil.AllPackages(pkgs, ssa.SanityCheckFunctions)
+	prog.Build()
+
+	var cg *callgraph.Graph
+	switch a.algo {
+	case AlgoStatic:
+		cg = static.CallGraph(prog)
+	case AlgoCHA:
+		cg = cha.CallGraph(prog)
+	case AlgoRTA:
+		roots := mainFunctions(ssaPkgs)
+		if len(roots) == 0 {
+			return nil, fmt.Errorf("analyzeTyped: rta requires at least one main package under -path")
+		}
+		rtaResult := rta.Analyze(roots, true)
+		cg = rtaResult.CallGraph
+	case AlgoVTA:
+		cg = vta.CallGraph(ssautil.AllFunctions(prog), cha.CallGraph(prog))
+	default:
+		return nil, fmt.Errorf("analyzeTyped: unsupported algorithm %q", a.algo)
+	}
+
+	result := &CallGraph{
+		Functions: make(map[string]*Function),
+		Calls:     make([]Call, 0),
+		Packages:  make(map[string]bool),
+	}
+
+	err = callgraph.GraphVisitEdges(cg, func(edge *callgraph.Edge) error {
+		caller, callee := edge.Caller.Func, edge.Callee.Func
+		if caller == nil || callee == nil || caller.Pkg == nil {
+			// Edges from the synthetic root node or into packages we
+			// never loaded (e.g. runtime) aren't useful in the graph.
+			return nil
+		}
+		scope := calleeScope(callee, a.modulePath)
+		if !a.scope.allows(scope) {
+			return nil
+		}
+
+		recordSSAFunction(result, prog.Fset, caller)
+		recordSSAFunction(result, prog.Fset, callee)
+
+		result.Calls = append(result.Calls, Call{
+			From:  ssaFuncName(caller),
+			To:    ssaFuncName(callee),
+			Algo:  a.algo,
+			Scope: scope,
+			Pos:   prog.Fset.Position(edge.Site.Pos()),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// mainFunctions returns the main and init functions of every "main"
+// package, used as RTA's reachability roots.
+func mainFunctions(pkgs []*ssa.Package) []*ssa.Function {
+	var roots []*ssa.Function
+	for _, pkg := range pkgs {
+		if pkg == nil || pkg.Pkg.Name() != "main" {
+			continue
+		}
+		if fn := pkg.Func("main"); fn != nil {
+			roots = append(roots, fn)
+		}
+		if fn := pkg.Func("init"); fn != nil {
+			roots = append(roots, fn)
+		}
+	}
+	return roots
+}
+
+// recordSSAFunction adds fn to cg.Functions/Packages if it isn't already
+// present.
+func recordSSAFunction(cg *CallGraph, fset *token.FileSet, fn *ssa.Function) {
+	if fn == nil || fn.Pkg == nil {
+		return
+	}
+	name := ssaFuncName(fn)
+	if _, ok := cg.Functions[name]; ok {
+		return
+	}
+
+	pkgPath := fn.Pkg.Pkg.Path()
+	cg.Packages[pkgPath] = true
+
+	receiver := ""
+	if recv := fn.Signature.Recv(); recv != nil {
+		receiver = receiverTypeName(recv.Type())
+	}
+
+	exported := false
+	if obj := fn.Object(); obj != nil {
+		exported = obj.Exported()
+	}
+
+	cg.Functions[name] = &Function{
+		Name:       fn.Name(),
+		Package:    pkgPath,
+		File:       fset.Position(fn.Pos()).Filename,
+		Receiver:   receiver,
+		IsExported: exported,
+	}
+}
+
+// ssaFuncName builds the fully-qualified name we key the graph on:
+// <import path>.<Function>, or <import path>.<Receiver>.<Method>.
+func ssaFuncName(fn *ssa.Function) string {
+	if fn.Pkg == nil {
+		return fn.String()
+	}
+	pkgPath := fn.Pkg.Pkg.Path()
+	if recv := fn.Signature.Recv(); recv != nil {
+		return pkgPath + "." + receiverTypeName(recv.Type()) + "." + fn.Name()
+	}
+	return pkgPath + "." + fn.Name()
+}
+
+// receiverTypeName strips the pointer indirection off a receiver type and
+// returns its declared name.
+func receiverTypeName(t types.Type) string {
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	if named, ok := t.(*types.Named); ok {
+		return named.Obj().Name()
+	}
+	return t.String()
+}
+
+// calleeScope classifies fn by Scope. The SSA builder synthesizes
+// wrapper functions - bound-method closures, interface method thunks -
+// for which fn.Pkg is nil even though the method itself is perfectly
+// ordinary (most commonly one on a stdlib type, e.g. bound$(*os.File).Close).
+// Fall back to the receiver's declaring package in that case instead of
+// defaulting to module scope, which would silently defeat
+// -scope=module/-scope=stdlib for every such edge.
+func calleeScope(fn *ssa.Function, modulePath string) Scope {
+	if fn.Pkg != nil {
+		return classifyImport(fn.Pkg.Pkg.Path(), modulePath)
+	}
+	if recv := fn.Signature.Recv(); recv != nil {
+		if path := namedTypePkgPath(recv.Type()); path != "" {
+			return classifyImport(path, modulePath)
+		}
+	}
+	// No package information recoverable at all (e.g. a wrapper around
+	// a bare function value). Default to module scope so the edge still
+	// shows up rather than risk hiding it under -scope=module/stdlib.
+	return ScopeModule
+}
+
+// namedTypePkgPath returns the import path of the package t's underlying
+// named type was declared in, or "" if t isn't a (possibly pointer-to)
+// named type.
+func namedTypePkgPath(t types.Type) string {
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	named, ok := t.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return ""
+	}
+	return named.Obj().Pkg().Path()
+}
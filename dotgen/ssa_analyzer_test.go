@@ -0,0 +1,57 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestAnalyzeTypedScopeModuleExcludesStdlib guards against a bug where
+// synthetic SSA wrapper functions (bound methods, interface thunks -
+// anything with fn.Pkg == nil, the norm for compiler-synthesized methods
+// on stdlib types) defaulted to module scope regardless of where they
+// actually lived, silently defeating -scope=module/-scope=stdlib for the
+// CHA/RTA/VTA algorithms.
+func TestAnalyzeTypedScopeModuleExcludesStdlib(t *testing.T) {
+	path, err := filepath.Abs("example-project")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a := NewAnalyzer(path, "", false, AlgoCHA, 1, "", ScopeModule)
+	cg, err := a.Analyze()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cg.Calls) == 0 {
+		t.Fatal("expected at least one call in example-project's graph")
+	}
+	for _, call := range cg.Calls {
+		if call.Scope != ScopeModule {
+			t.Errorf("-scope=module: got edge %s -> %s classified as %q, want %q",
+				call.From, call.To, call.Scope, ScopeModule)
+		}
+	}
+}
+
+// TestAnalyzeTypedRTANoMainReturnsError exercises -algo rta against a
+// directory with no package main (example-project/utils, a real
+// dependency-free leaf package in this module). rta.Analyze panics on a
+// nil/empty root slice, so analyzeTyped must refuse before calling it
+// rather than crash on input that's otherwise completely valid for this
+// tool.
+func TestAnalyzeTypedRTANoMainReturnsError(t *testing.T) {
+	path, err := filepath.Abs(filepath.Join("example-project", "utils"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a := NewAnalyzer(path, "", false, AlgoRTA, 1, "", ScopeAll)
+	_, err = a.Analyze()
+	if err == nil {
+		t.Fatal("expected an error analyzing a main-less package with -algo rta, got nil")
+	}
+	if !strings.Contains(err.Error(), "main package") {
+		t.Errorf("expected error to mention the missing main package, got: %v", err)
+	}
+}